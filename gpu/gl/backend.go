@@ -21,6 +21,26 @@ type Backend struct {
 	state glstate
 
 	feats gpu.Caps
+	// hasUBO tracks whether the context supports real uniform buffer
+	// objects (GLES 3.0+ or desktop GL 3.1+). Older contexts fall back to
+	// emulating uniform buffers with Uniform{1..4}f calls every frame.
+	hasUBO bool
+	// glslVariant is the GLSL dialect NewProgram selects shader sources
+	// and preambles for.
+	glslVariant glslVariant
+	// hasKHRDebug records GL_KHR_debug support, detected in NewBackend.
+	// glObjectLabel, glPushDebugGroup/glPopDebugGroup and the debug message
+	// log are all GL_KHR_debug entry points; there is no fallback to the
+	// incompatible GL_EXT_debug_label for object labeling.
+	hasKHRDebug bool
+	// debugMessages, when set via SetDebugMessageHandler, receives
+	// GL_KHR_debug messages drained at the end of every frame.
+	debugMessages func(source, msgType, severity Enum, id uint32, message string)
+	// occlusionBoolOnly is true when occlusion queries are only available
+	// through the GL_EXT_occlusion_query_boolean fallback. Like the core
+	// ANY_SAMPLES_PASSED query, it reports whether any sample passed, not
+	// a count.
+	occlusionBoolOnly bool
 	// floatTriple holds the settings for floating point
 	// textures.
 	floatTriple textureTriple
@@ -50,6 +70,15 @@ type gpuTimer struct {
 	obj   Query
 }
 
+type gpuOcclusionQuery struct {
+	funcs Functions
+	obj   Query
+	// boolOnly is true when the query uses the
+	// GL_EXT_occlusion_query_boolean target instead of the core one; both
+	// report only whether any sample passed, not a count.
+	boolOnly bool
+}
+
 type gpuTexture struct {
 	backend *Backend
 	obj     Texture
@@ -57,8 +86,9 @@ type gpuTexture struct {
 }
 
 type gpuFramebuffer struct {
-	funcs Functions
-	obj   Framebuffer
+	backend *Backend
+	funcs   Functions
+	obj     Framebuffer
 }
 
 type gpuBuffer struct {
@@ -68,7 +98,12 @@ type gpuBuffer struct {
 	size      int
 	immutable bool
 	version   int
-	// For emulation of uniform buffers.
+	// allocated tracks whether obj has had its storage sized by a
+	// previous BufferData call, so that later uploads of up to size bytes
+	// can use the cheaper BufferSubData instead.
+	allocated bool
+	// data is non-nil when the uniform buffer is emulated in CPU memory,
+	// for backends without native uniform buffer object support.
 	data []byte
 }
 
@@ -85,6 +120,10 @@ type uniformsTracker struct {
 	size    int
 	buf     *gpuBuffer
 	version int
+	// native is true when uniforms are backed by a real uniform buffer
+	// object bound at binding, rather than emulated with locs.
+	native  bool
+	binding int
 }
 
 type uniformLocation struct {
@@ -109,6 +148,7 @@ type textureTriple struct {
 }
 
 func NewBackend(f Functions) (*Backend, error) {
+	f = wrapDebug(f)
 	exts := strings.Split(f.GetString(EXTENSIONS), " ")
 	glVer := f.GetString(VERSION)
 	ver, err := ParseGLVersion(glVer)
@@ -125,16 +165,34 @@ func NewBackend(f Functions) (*Backend, error) {
 	}
 	defFBO := Framebuffer(f.GetBinding(FRAMEBUFFER_BINDING))
 	b := &Backend{
-		defFBO:      &gpuFramebuffer{funcs: f, obj: defFBO},
 		funcs:       f,
 		floatTriple: floatTriple,
 		alphaTriple: alphaTripleFor(ver),
 		srgbaTriple: srgbaTriple,
+		// GLES 3.0 and GL 3.1 both introduced uniform buffer objects.
+		hasUBO:      ver[0] > 3 || (ver[0] == 3 && (ver[1] >= 1 || strings.Contains(glVer, "OpenGL ES"))),
+		glslVariant: detectGLSLVariant(glVer, ver),
 	}
 	if hasExtension(exts, "GL_EXT_disjoint_timer_query_webgl2") || hasExtension(exts, "GL_EXT_disjoint_timer_query") {
 		b.feats.Features |= gpu.FeatureTimers
 	}
+	b.hasKHRDebug = hasExtension(exts, "GL_KHR_debug")
+	if b.hasKHRDebug {
+		// Without enabling debug output, the driver never populates the
+		// log GetDebugMessageLog (and so drainDebugMessages) reads from.
+		f.Enable(DEBUG_OUTPUT)
+		f.Enable(DEBUG_OUTPUT_SYNCHRONOUS)
+	}
+	switch {
+	// ANY_SAMPLES_PASSED is core in GLES 3.0 and desktop GL 3.3.
+	case ver[0] > 3 || (ver[0] == 3 && (ver[1] >= 3 || strings.Contains(glVer, "OpenGL ES"))):
+		b.feats.Features |= gpu.FeatureOcclusion
+	case hasExtension(exts, "GL_EXT_occlusion_query_boolean"):
+		b.feats.Features |= gpu.FeatureOcclusion
+		b.occlusionBoolOnly = true
+	}
 	b.feats.MaxTextureSize = f.GetInteger(MAX_TEXTURE_SIZE)
+	b.defFBO = &gpuFramebuffer{backend: b, funcs: f, obj: defFBO}
 	return b, nil
 }
 
@@ -145,6 +203,7 @@ func (b *Backend) BeginFrame() {
 
 func (b *Backend) EndFrame() {
 	b.funcs.ActiveTexture(TEXTURE0)
+	b.drainDebugMessages()
 }
 
 func (b *Backend) Caps() gpu.Caps {
@@ -162,9 +221,21 @@ func (b *Backend) IsTimeContinuous() bool {
 	return b.funcs.GetInteger(GPU_DISJOINT_EXT) == FALSE
 }
 
+// NewOcclusionQuery creates a query reporting whether any sample passed the
+// depth/stencil tests for the draws between its Begin and End, for
+// picking and visibility culling. It reports 0 or 1, not a sample count.
+// Its capability is advertised as gpu.FeatureOcclusion in Caps.
+func (b *Backend) NewOcclusionQuery() gpu.OcclusionQuery {
+	return &gpuOcclusionQuery{
+		funcs:    b.funcs,
+		obj:      b.funcs.CreateQuery(),
+		boolOnly: b.occlusionBoolOnly,
+	}
+}
+
 func (b *Backend) NewFramebuffer() gpu.Framebuffer {
 	fb := b.funcs.CreateFramebuffer()
-	return &gpuFramebuffer{funcs: b.funcs, obj: fb}
+	return &gpuFramebuffer{backend: b, funcs: b.funcs, obj: fb}
 }
 
 func (b *Backend) NilTexture() gpu.Texture {
@@ -197,12 +268,19 @@ func (b *Backend) NewTexture(format gpu.TextureFormat, width, height int, minFil
 func (b *Backend) NewBuffer(typ gpu.BufferType, size int) gpu.Buffer {
 	gltyp := toBufferType(typ)
 	buf := &gpuBuffer{backend: b, typ: gltyp, size: size}
-	switch typ {
-	case gpu.BufferTypeUniforms:
-		// GLES 2 doesn't support uniform buffers.
+	switch {
+	case typ == gpu.BufferTypeUniforms && !b.hasUBO:
+		// Emulate uniform buffers on backends without UBO support.
 		buf.data = make([]byte, size)
 	default:
 		buf.obj = b.funcs.CreateBuffer()
+		// Size the GL store to size up front, so that every later Upload -
+		// whatever its length, and vertex and index buffers are routinely
+		// re-uploaded at different lengths each frame - can use the cheaper
+		// BufferSubData instead of risking writing past a smaller store.
+		b.funcs.BindBuffer(gltyp, buf.obj)
+		b.funcs.BufferData(gltyp, make([]byte, size), STATIC_DRAW)
+		buf.allocated = true
 	}
 	return buf
 }
@@ -283,6 +361,8 @@ func (b *Backend) SetBlend(enable bool) {
 }
 
 func (b *Backend) DrawElements(mode gpu.DrawMode, off, count int) {
+	b.PushDebugGroup("DrawElements")
+	defer b.PopDebugGroup()
 	b.prepareDraw()
 	// off is in 16-bit indices, but DrawElements take a byte offset.
 	byteOff := off * 2
@@ -290,6 +370,8 @@ func (b *Backend) DrawElements(mode gpu.DrawMode, off, count int) {
 }
 
 func (b *Backend) DrawArrays(mode gpu.DrawMode, off, count int) {
+	b.PushDebugGroup("DrawArrays")
+	defer b.PopDebugGroup()
 	b.prepareDraw()
 	b.funcs.DrawArrays(toGLDrawMode(mode), off, count)
 }
@@ -367,7 +449,8 @@ func (b *Backend) NewProgram(vssrc, fssrc gpu.ShaderSources) (gpu.Program, error
 	for _, inp := range vssrc.Inputs {
 		attr[inp.Location] = inp.Name
 	}
-	p, err := CreateProgram(b.funcs, vssrc.GLES2, fssrc.GLES2, attr)
+	vs, fs := b.shaderSource(vssrc, false), b.shaderSource(fssrc, true)
+	p, err := CreateProgram(b.funcs, vs, fs, attr)
 	if err != nil {
 		return nil, err
 	}
@@ -390,8 +473,10 @@ func (b *Backend) NewProgram(vssrc, fssrc gpu.ShaderSources) (gpu.Program, error
 			b.funcs.Uniform1i(u, tex.Binding)
 		}
 	}
-	gpuProg.vertUniforms.setup(b.funcs, p, vssrc.UniformSize, vssrc.Uniforms)
-	gpuProg.fragUniforms.setup(b.funcs, p, fssrc.UniformSize, fssrc.Uniforms)
+	// Binding 0 is reserved for vertex-stage uniforms, 1 for fragment-stage
+	// uniforms; a program only ever has the two blocks below.
+	gpuProg.vertUniforms.setup(b, p, vssrc.UniformSize, vssrc.Uniforms, vssrc.UniformsBlockName, 0)
+	gpuProg.fragUniforms.setup(b, p, fssrc.UniformSize, fssrc.Uniforms, fssrc.UniformsBlockName, 1)
 	return gpuProg, nil
 }
 
@@ -422,12 +507,20 @@ func (p *gpuProgram) Release() {
 	p.backend.funcs.DeleteProgram(p.obj)
 }
 
-func (u *uniformsTracker) setup(funcs Functions, p Program, uniformSize int, uniforms []gpu.UniformLocation) {
+func (u *uniformsTracker) setup(b *Backend, p Program, uniformSize int, uniforms []gpu.UniformLocation, blockName string, binding int) {
+	u.size = uniformSize
+	if b.hasUBO && blockName != "" {
+		if idx := b.funcs.GetUniformBlockIndex(p, blockName); idx != INVALID_INDEX {
+			b.funcs.UniformBlockBinding(p, idx, binding)
+			u.native = true
+			u.binding = binding
+			return
+		}
+	}
 	u.locs = make([]uniformLocation, len(uniforms))
 	for i, uniform := range uniforms {
-		u.locs[i] = lookupUniform(funcs, p, uniform)
+		u.locs[i] = lookupUniform(b.funcs, p, uniform)
 	}
-	u.size = uniformSize
 }
 
 func (u *uniformsTracker) setBuffer(buffer gpu.Buffer) {
@@ -449,6 +542,10 @@ func (p *uniformsTracker) update(funcs Functions) {
 		return
 	}
 	p.version = b.version
+	if p.native {
+		funcs.BindBufferRange(UNIFORM_BUFFER, p.binding, b.obj, 0, p.size)
+		return
+	}
 	data := b.data
 	for _, u := range p.locs {
 		data := data[u.offset:]
@@ -483,21 +580,25 @@ func (b *gpuBuffer) Upload(data []byte) {
 		panic("buffer size overflow")
 	}
 	b.version++
-	switch b.typ {
-	case UNIFORM_BUFFER:
+	if b.data != nil {
 		copy(b.data, data)
-	default:
-		b.backend.funcs.BindBuffer(b.typ, b.obj)
+		return
+	}
+	b.backend.funcs.BindBuffer(b.typ, b.obj)
+	if b.allocated {
+		b.backend.funcs.BufferSubData(b.typ, 0, data)
+	} else {
 		b.backend.funcs.BufferData(b.typ, data, STATIC_DRAW)
+		b.allocated = true
 	}
 }
 
 func (b *gpuBuffer) Release() {
-	switch b.typ {
-	case UNIFORM_BUFFER:
-	default:
-		b.backend.funcs.DeleteBuffer(b.obj)
+	if b.data != nil {
+		// Emulated uniform buffer; nothing allocated on the GPU.
+		return
 	}
+	b.backend.funcs.DeleteBuffer(b.obj)
 }
 
 func (b *gpuBuffer) BindVertex(stride, offset int) {
@@ -619,6 +720,41 @@ func (t *gpuTimer) Duration() (time.Duration, bool) {
 	return time.Duration(nanos), true
 }
 
+func (q *gpuOcclusionQuery) target() Enum {
+	if q.boolOnly {
+		return ANY_SAMPLES_PASSED_EXT
+	}
+	return ANY_SAMPLES_PASSED
+}
+
+func (q *gpuOcclusionQuery) Begin() {
+	q.funcs.BeginQuery(q.target(), q.obj)
+}
+
+func (q *gpuOcclusionQuery) End() {
+	q.funcs.EndQuery(q.target())
+}
+
+func (q *gpuOcclusionQuery) ready() bool {
+	return q.funcs.GetQueryObjectuiv(q.obj, QUERY_RESULT_AVAILABLE) == TRUE
+}
+
+func (q *gpuOcclusionQuery) Release() {
+	q.funcs.DeleteQuery(q.obj)
+}
+
+// SamplesPassed reports whether any sample passed the depth/stencil tests
+// for the draws between Begin and End. Despite the uint32 return type, this
+// is always a boolean 0/1: neither GL_ANY_SAMPLES_PASSED nor its
+// GL_EXT_occlusion_query_boolean fallback reports a true sample count. The
+// second return value is false until the result becomes available.
+func (q *gpuOcclusionQuery) SamplesPassed() (uint32, bool) {
+	if !q.ready() {
+		return 0, false
+	}
+	return uint32(q.funcs.GetQueryObjectuiv(q.obj, QUERY_RESULT)), true
+}
+
 func (l *gpuInputLayout) Bind() {
 	l.backend.state.layout = l
 }
@@ -686,6 +822,94 @@ func alphaTripleFor(ver [2]int) textureTriple {
 	return textureTriple{intf, f, UNSIGNED_BYTE}
 }
 
+// glslVariant identifies a GLSL dialect NewProgram can target.
+type glslVariant int
+
+const (
+	glslES2 glslVariant = iota
+	glslES3
+	glslGL330
+)
+
+// detectGLSLVariant picks the richest GLSL dialect the context can compile:
+// GLSL ES 3.00 on GLES 3.0+, GLSL 3.30 core on desktop GL 3.3+, and the
+// GLSL ES 1.00 shims everywhere else.
+func detectGLSLVariant(glVer string, ver [2]int) glslVariant {
+	isES := strings.Contains(glVer, "OpenGL ES")
+	switch {
+	case isES && ver[0] >= 3:
+		return glslES3
+	case !isES && (ver[0] > 3 || (ver[0] == 3 && ver[1] >= 3)):
+		return glslGL330
+	default:
+		return glslES2
+	}
+}
+
+// preamble returns the #version directive and the macro shims that let a
+// single shader source, written against the VSIN/VSOUT/FSIN/TEXTURE/
+// FRAGCOLOR macros, compile across GLSL ES 1.00, GLSL ES 3.00 and GLSL
+// 3.30 core without modification. isFragment selects the fragment-stage
+// shims (FSIN, FRAGCOLOR and the fragColor output declaration); a vertex
+// shader must not declare those.
+func (v glslVariant) preamble(isFragment bool) string {
+	switch v {
+	case glslES3:
+		return "#version 300 es\n" + glsl3Defines(isFragment)
+	case glslGL330:
+		return "#version 330\n" + glsl3Defines(isFragment)
+	default:
+		return glslES2Defines(isFragment)
+	}
+}
+
+func glsl3Defines(isFragment bool) string {
+	s := "" +
+		"#define VSIN(loc) layout(location = loc) in\n" +
+		"#define VSOUT out\n" +
+		"#define TEXTURE texture\n"
+	if isFragment {
+		s += "" +
+			"#define FSIN in\n" +
+			"#define FRAGCOLOR(c) fragColor = c\n" +
+			"out vec4 fragColor;\n"
+	}
+	return s
+}
+
+func glslES2Defines(isFragment bool) string {
+	s := "" +
+		"#version 100\n" +
+		"#define VSIN(loc) attribute\n" +
+		"#define VSOUT varying\n" +
+		"#define TEXTURE texture2D\n"
+	if isFragment {
+		s += "" +
+			"#define FSIN varying\n" +
+			"#define FRAGCOLOR(c) gl_FragColor = c\n"
+	}
+	return s
+}
+
+// shaderSource returns the source to compile for the backend's selected
+// GLSL variant. Shaders without a source for that variant fall back to the
+// GLES2 source, with the GLSL ES 1.00 preamble explicitly prepended rather
+// than relying on the driver to accept a legacy attribute/varying/
+// gl_FragColor shader under a newer context.
+func (b *Backend) shaderSource(src gpu.ShaderSources, isFragment bool) string {
+	switch b.glslVariant {
+	case glslES3:
+		if src.GLES3 != "" {
+			return glslES3.preamble(isFragment) + src.GLES3
+		}
+	case glslGL330:
+		if src.GL330 != "" {
+			return glslGL330.preamble(isFragment) + src.GL330
+		}
+	}
+	return glslES2.preamble(isFragment) + src.GLES2
+}
+
 func hasExtension(exts []string, ext string) bool {
 	for _, e := range exts {
 		if ext == e {
@@ -0,0 +1,106 @@
+// SPDX-License-Identifier: Unlicense OR MIT
+
+package gl
+
+// GL constants, named after their OpenGL (ES) counterparts. They are left
+// untyped so each can be used as both an Enum (e.g. a BindBuffer target)
+// and a plain int (e.g. a textureTriple.internalFormat or TexParameteri
+// param) without a conversion at every use.
+const (
+	NO_ERROR                      = 0x0000
+	INVALID_ENUM                  = 0x0500
+	INVALID_VALUE                 = 0x0501
+	INVALID_OPERATION             = 0x0502
+	OUT_OF_MEMORY                 = 0x0505
+	INVALID_FRAMEBUFFER_OPERATION = 0x0506
+
+	FALSE = 0
+	TRUE  = 1
+
+	DEPTH_BUFFER_BIT = 0x00000100
+	COLOR_BUFFER_BIT = 0x00004000
+
+	TRIANGLES      = 0x0004
+	TRIANGLE_STRIP = 0x0005
+
+	ZERO                = 0
+	ONE                 = 1
+	ONE_MINUS_SRC_ALPHA = 0x0303
+	DST_COLOR           = 0x0306
+
+	GREATER    = 0x0204
+	DEPTH_TEST = 0x0B71
+	BLEND      = 0x0BE2
+
+	UNSIGNED_BYTE  = 0x1401
+	SHORT          = 0x1402
+	UNSIGNED_SHORT = 0x1403
+	FLOAT          = 0x1406
+
+	RED       = 0x1903
+	RGBA      = 0x1908
+	LUMINANCE = 0x1909
+
+	NEAREST            = 0x2600
+	LINEAR             = 0x2601
+	TEXTURE_MAG_FILTER = 0x2800
+	TEXTURE_MIN_FILTER = 0x2801
+	TEXTURE_WRAP_S     = 0x2802
+	TEXTURE_WRAP_T     = 0x2803
+
+	TEXTURE_2D = 0x0DE1
+	TEXTURE0   = 0x84C0
+
+	ARRAY_BUFFER         = 0x8892
+	ELEMENT_ARRAY_BUFFER = 0x8893
+	STATIC_DRAW          = 0x88E4
+	UNIFORM_BUFFER       = 0x8A11
+
+	FRAMEBUFFER          = 0x8D40
+	FRAMEBUFFER_BINDING  = 0x8CA6
+	FRAMEBUFFER_COMPLETE = 0x8CD5
+	COLOR_ATTACHMENT0    = 0x8CE0
+	CLAMP_TO_EDGE        = 0x812F
+
+	R8           = 0x8229
+	R16F         = 0x822D
+	SRGB8_ALPHA8 = 0x8C43
+
+	HALF_FLOAT     = 0x140B
+	HALF_FLOAT_OES = 0x8D61
+
+	SRGB_ALPHA_EXT = 0x8C42
+
+	MAX_TEXTURE_SIZE = 0x0D33
+	EXTENSIONS       = 0x1F03
+	VERSION          = 0x1F02
+
+	TIME_ELAPSED_EXT       = 0x88BF
+	GPU_DISJOINT_EXT       = 0x8FBB
+	QUERY_RESULT           = 0x8866
+	QUERY_RESULT_AVAILABLE = 0x8867
+
+	// Occlusion queries (GL_KHR_occlusion_query / core 3.3, with the
+	// GL_EXT_occlusion_query_boolean fallback on GLES2). Both report only
+	// whether any sample passed, not a count.
+	ANY_SAMPLES_PASSED     = 0x8C2F
+	ANY_SAMPLES_PASSED_EXT = 0x8C2F
+
+	// GL_KHR_debug.
+	DEBUG_OUTPUT             = 0x92E0
+	DEBUG_OUTPUT_SYNCHRONOUS = 0x8242
+	DEBUG_SOURCE_APPLICATION = 0x824A
+
+	// Shader compilation and program linking.
+	FRAGMENT_SHADER = 0x8B30
+	VERTEX_SHADER   = 0x8B31
+	COMPILE_STATUS  = 0x8B81
+	LINK_STATUS     = 0x8B82
+
+	// glObjectLabel identifiers. These are distinct from the bind-target
+	// enums above (e.g. TEXTURE_2D, ARRAY_BUFFER) even where they share a
+	// name in spirit.
+	TEXTURE = 0x1702
+	BUFFER  = 0x82E0
+	PROGRAM = 0x82E2
+)
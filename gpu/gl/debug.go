@@ -0,0 +1,473 @@
+// SPDX-License-Identifier: Unlicense OR MIT
+
+//go:build gldebug
+// +build gldebug
+
+package gl
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"sync/atomic"
+)
+
+// debugFunctions wraps a Functions implementation, logging every call,
+// draining glGetError after it and panicking on the first unexpected
+// error, and detecting reentrant use of the wrapped context from more
+// than one goroutine at a time. It is modeled on the gldebug wrapper in
+// golang.org/x/mobile/gl and is enabled by building with the gldebug
+// build tag.
+type debugFunctions struct {
+	Functions
+	// active is 1 while a call is in flight on the wrapped context, used
+	// to detect concurrent use from more than one goroutine.
+	active uint32
+}
+
+// wrapDebug wraps f in a debugFunctions when built with the gldebug tag.
+func wrapDebug(f Functions) Functions {
+	return &debugFunctions{Functions: f}
+}
+
+func (d *debugFunctions) enter(name string, args ...interface{}) {
+	if !atomic.CompareAndSwapUint32(&d.active, 0, 1) {
+		panic(fmt.Sprintf("gldebug: concurrent call to %s while another call is in flight", name))
+	}
+	log.Printf("gl: %s(%s)", name, formatArgs(args))
+}
+
+func (d *debugFunctions) exit(name string) {
+	if !atomic.CompareAndSwapUint32(&d.active, 1, 0) {
+		panic(fmt.Sprintf("gldebug: %s returned while context was not marked active", name))
+	}
+	var errs []string
+	for {
+		errc := d.Functions.GetError()
+		if errc == NO_ERROR {
+			break
+		}
+		errs = append(errs, errc.String())
+	}
+	if len(errs) > 0 {
+		panic(fmt.Sprintf("gldebug: %s: glGetError returned %s", name, strings.Join(errs, ", ")))
+	}
+}
+
+func formatArgs(args []interface{}) string {
+	s := ""
+	for i, a := range args {
+		if i > 0 {
+			s += ", "
+		}
+		s += fmt.Sprintf("%v", a)
+	}
+	return s
+}
+
+func (d *debugFunctions) ActiveTexture(texture Enum) {
+	d.enter("ActiveTexture", texture)
+	defer d.exit("ActiveTexture")
+	d.Functions.ActiveTexture(texture)
+}
+
+func (d *debugFunctions) AttachShader(p Program, s Shader) {
+	d.enter("AttachShader", p, s)
+	defer d.exit("AttachShader")
+	d.Functions.AttachShader(p, s)
+}
+
+func (d *debugFunctions) BeginQuery(target Enum, query Query) {
+	d.enter("BeginQuery", target, query)
+	defer d.exit("BeginQuery")
+	d.Functions.BeginQuery(target, query)
+}
+
+func (d *debugFunctions) BindAttribLocation(p Program, a Attrib, name string) {
+	d.enter("BindAttribLocation", p, a, name)
+	defer d.exit("BindAttribLocation")
+	d.Functions.BindAttribLocation(p, a, name)
+}
+
+func (d *debugFunctions) BindBuffer(target Enum, buffer Buffer) {
+	d.enter("BindBuffer", target, buffer)
+	defer d.exit("BindBuffer")
+	d.Functions.BindBuffer(target, buffer)
+}
+
+func (d *debugFunctions) BindFramebuffer(target Enum, fbo Framebuffer) {
+	d.enter("BindFramebuffer", target, fbo)
+	defer d.exit("BindFramebuffer")
+	d.Functions.BindFramebuffer(target, fbo)
+}
+
+func (d *debugFunctions) BindTexture(target Enum, texture Texture) {
+	d.enter("BindTexture", target, texture)
+	defer d.exit("BindTexture")
+	d.Functions.BindTexture(target, texture)
+}
+
+func (d *debugFunctions) BlendFunc(sfactor, dfactor Enum) {
+	d.enter("BlendFunc", sfactor, dfactor)
+	defer d.exit("BlendFunc")
+	d.Functions.BlendFunc(sfactor, dfactor)
+}
+
+func (d *debugFunctions) BufferData(target Enum, src []byte, usage Enum) {
+	d.enter("BufferData", target, len(src), usage)
+	defer d.exit("BufferData")
+	d.Functions.BufferData(target, src, usage)
+}
+
+func (d *debugFunctions) BufferSubData(target Enum, offset int, src []byte) {
+	d.enter("BufferSubData", target, offset, len(src))
+	defer d.exit("BufferSubData")
+	d.Functions.BufferSubData(target, offset, src)
+}
+
+func (d *debugFunctions) BindBufferBase(target Enum, index int, buffer Buffer) {
+	d.enter("BindBufferBase", target, index, buffer)
+	defer d.exit("BindBufferBase")
+	d.Functions.BindBufferBase(target, index, buffer)
+}
+
+func (d *debugFunctions) BindBufferRange(target Enum, index int, buffer Buffer, offset, size int) {
+	d.enter("BindBufferRange", target, index, buffer, offset, size)
+	defer d.exit("BindBufferRange")
+	d.Functions.BindBufferRange(target, index, buffer, offset, size)
+}
+
+func (d *debugFunctions) GetUniformBlockIndex(p Program, name string) uint32 {
+	d.enter("GetUniformBlockIndex", p, name)
+	defer d.exit("GetUniformBlockIndex")
+	return d.Functions.GetUniformBlockIndex(p, name)
+}
+
+func (d *debugFunctions) UniformBlockBinding(p Program, index uint32, binding int) {
+	d.enter("UniformBlockBinding", p, index, binding)
+	defer d.exit("UniformBlockBinding")
+	d.Functions.UniformBlockBinding(p, index, binding)
+}
+
+func (d *debugFunctions) PushDebugGroup(source Enum, id uint32, message string) {
+	d.enter("PushDebugGroup", source, id, message)
+	defer d.exit("PushDebugGroup")
+	d.Functions.PushDebugGroup(source, id, message)
+}
+
+func (d *debugFunctions) PopDebugGroup() {
+	d.enter("PopDebugGroup")
+	defer d.exit("PopDebugGroup")
+	d.Functions.PopDebugGroup()
+}
+
+func (d *debugFunctions) ObjectLabel(identifier Enum, obj Object, label string) {
+	d.enter("ObjectLabel", identifier, obj, label)
+	defer d.exit("ObjectLabel")
+	d.Functions.ObjectLabel(identifier, obj, label)
+}
+
+func (d *debugFunctions) GetDebugMessageLog() (DebugMessage, bool) {
+	// Not logged: this is itself the channel gldebug's own tracing could
+	// recurse through if the driver ever queued a message about it.
+	return d.Functions.GetDebugMessageLog()
+}
+
+func (d *debugFunctions) CheckFramebufferStatus(target Enum) Enum {
+	d.enter("CheckFramebufferStatus", target)
+	defer d.exit("CheckFramebufferStatus")
+	return d.Functions.CheckFramebufferStatus(target)
+}
+
+func (d *debugFunctions) Clear(mask Enum) {
+	d.enter("Clear", mask)
+	defer d.exit("Clear")
+	d.Functions.Clear(mask)
+}
+
+func (d *debugFunctions) ClearColor(red, green, blue, alpha float32) {
+	d.enter("ClearColor", red, green, blue, alpha)
+	defer d.exit("ClearColor")
+	d.Functions.ClearColor(red, green, blue, alpha)
+}
+
+func (d *debugFunctions) ClearDepthf(depth float32) {
+	d.enter("ClearDepthf", depth)
+	defer d.exit("ClearDepthf")
+	d.Functions.ClearDepthf(depth)
+}
+
+func (d *debugFunctions) CompileShader(s Shader) {
+	d.enter("CompileShader", s)
+	defer d.exit("CompileShader")
+	d.Functions.CompileShader(s)
+}
+
+func (d *debugFunctions) CreateBuffer() Buffer {
+	d.enter("CreateBuffer")
+	defer d.exit("CreateBuffer")
+	return d.Functions.CreateBuffer()
+}
+
+func (d *debugFunctions) CreateFramebuffer() Framebuffer {
+	d.enter("CreateFramebuffer")
+	defer d.exit("CreateFramebuffer")
+	return d.Functions.CreateFramebuffer()
+}
+
+func (d *debugFunctions) CreateProgram() Program {
+	d.enter("CreateProgram")
+	defer d.exit("CreateProgram")
+	return d.Functions.CreateProgram()
+}
+
+func (d *debugFunctions) CreateQuery() Query {
+	d.enter("CreateQuery")
+	defer d.exit("CreateQuery")
+	return d.Functions.CreateQuery()
+}
+
+func (d *debugFunctions) CreateShader(ty Enum) Shader {
+	d.enter("CreateShader", ty)
+	defer d.exit("CreateShader")
+	return d.Functions.CreateShader(ty)
+}
+
+func (d *debugFunctions) CreateTexture() Texture {
+	d.enter("CreateTexture")
+	defer d.exit("CreateTexture")
+	return d.Functions.CreateTexture()
+}
+
+func (d *debugFunctions) DeleteBuffer(v Buffer) {
+	d.enter("DeleteBuffer", v)
+	defer d.exit("DeleteBuffer")
+	d.Functions.DeleteBuffer(v)
+}
+
+func (d *debugFunctions) DeleteFramebuffer(v Framebuffer) {
+	d.enter("DeleteFramebuffer", v)
+	defer d.exit("DeleteFramebuffer")
+	d.Functions.DeleteFramebuffer(v)
+}
+
+func (d *debugFunctions) DeleteProgram(p Program) {
+	d.enter("DeleteProgram", p)
+	defer d.exit("DeleteProgram")
+	d.Functions.DeleteProgram(p)
+}
+
+func (d *debugFunctions) DeleteQuery(v Query) {
+	d.enter("DeleteQuery", v)
+	defer d.exit("DeleteQuery")
+	d.Functions.DeleteQuery(v)
+}
+
+func (d *debugFunctions) DeleteShader(s Shader) {
+	d.enter("DeleteShader", s)
+	defer d.exit("DeleteShader")
+	d.Functions.DeleteShader(s)
+}
+
+func (d *debugFunctions) DeleteTexture(v Texture) {
+	d.enter("DeleteTexture", v)
+	defer d.exit("DeleteTexture")
+	d.Functions.DeleteTexture(v)
+}
+
+func (d *debugFunctions) DepthFunc(f Enum) {
+	d.enter("DepthFunc", f)
+	defer d.exit("DepthFunc")
+	d.Functions.DepthFunc(f)
+}
+
+func (d *debugFunctions) DepthMask(mask bool) {
+	d.enter("DepthMask", mask)
+	defer d.exit("DepthMask")
+	d.Functions.DepthMask(mask)
+}
+
+func (d *debugFunctions) Disable(cap Enum) {
+	d.enter("Disable", cap)
+	defer d.exit("Disable")
+	d.Functions.Disable(cap)
+}
+
+func (d *debugFunctions) DisableVertexAttribArray(a Attrib) {
+	d.enter("DisableVertexAttribArray", a)
+	defer d.exit("DisableVertexAttribArray")
+	d.Functions.DisableVertexAttribArray(a)
+}
+
+func (d *debugFunctions) DrawArrays(mode Enum, first, count int) {
+	d.enter("DrawArrays", mode, first, count)
+	defer d.exit("DrawArrays")
+	d.Functions.DrawArrays(mode, first, count)
+}
+
+func (d *debugFunctions) DrawElements(mode Enum, count int, ty Enum, offset int) {
+	d.enter("DrawElements", mode, count, ty, offset)
+	defer d.exit("DrawElements")
+	d.Functions.DrawElements(mode, count, ty, offset)
+}
+
+func (d *debugFunctions) Enable(cap Enum) {
+	d.enter("Enable", cap)
+	defer d.exit("Enable")
+	d.Functions.Enable(cap)
+}
+
+func (d *debugFunctions) EnableVertexAttribArray(a Attrib) {
+	d.enter("EnableVertexAttribArray", a)
+	defer d.exit("EnableVertexAttribArray")
+	d.Functions.EnableVertexAttribArray(a)
+}
+
+func (d *debugFunctions) EndQuery(target Enum) {
+	d.enter("EndQuery", target)
+	defer d.exit("EndQuery")
+	d.Functions.EndQuery(target)
+}
+
+func (d *debugFunctions) FramebufferTexture2D(target, attachment, texTarget Enum, t Texture, level int) {
+	d.enter("FramebufferTexture2D", target, attachment, texTarget, t, level)
+	defer d.exit("FramebufferTexture2D")
+	d.Functions.FramebufferTexture2D(target, attachment, texTarget, t, level)
+}
+
+func (d *debugFunctions) GetBinding(pname Enum) Object {
+	d.enter("GetBinding", pname)
+	defer d.exit("GetBinding")
+	return d.Functions.GetBinding(pname)
+}
+
+func (d *debugFunctions) GetError() Enum {
+	// GetError is used by exit itself; avoid recursing into enter/exit.
+	return d.Functions.GetError()
+}
+
+func (d *debugFunctions) GetInteger(pname Enum) int {
+	d.enter("GetInteger", pname)
+	defer d.exit("GetInteger")
+	return d.Functions.GetInteger(pname)
+}
+
+func (d *debugFunctions) GetProgrami(p Program, pname Enum) int {
+	d.enter("GetProgrami", p, pname)
+	defer d.exit("GetProgrami")
+	return d.Functions.GetProgrami(p, pname)
+}
+
+func (d *debugFunctions) GetProgramInfoLog(p Program) string {
+	d.enter("GetProgramInfoLog", p)
+	defer d.exit("GetProgramInfoLog")
+	return d.Functions.GetProgramInfoLog(p)
+}
+
+func (d *debugFunctions) GetQueryObjectuiv(query Query, pname Enum) uint {
+	d.enter("GetQueryObjectuiv", query, pname)
+	defer d.exit("GetQueryObjectuiv")
+	return d.Functions.GetQueryObjectuiv(query, pname)
+}
+
+func (d *debugFunctions) GetShaderi(s Shader, pname Enum) int {
+	d.enter("GetShaderi", s, pname)
+	defer d.exit("GetShaderi")
+	return d.Functions.GetShaderi(s, pname)
+}
+
+func (d *debugFunctions) GetShaderInfoLog(s Shader) string {
+	d.enter("GetShaderInfoLog", s)
+	defer d.exit("GetShaderInfoLog")
+	return d.Functions.GetShaderInfoLog(s)
+}
+
+func (d *debugFunctions) GetString(pname Enum) string {
+	d.enter("GetString", pname)
+	defer d.exit("GetString")
+	return d.Functions.GetString(pname)
+}
+
+func (d *debugFunctions) GetUniformLocation(p Program, name string) Uniform {
+	d.enter("GetUniformLocation", p, name)
+	defer d.exit("GetUniformLocation")
+	return d.Functions.GetUniformLocation(p, name)
+}
+
+func (d *debugFunctions) InvalidateFramebuffer(target, attachment Enum) {
+	d.enter("InvalidateFramebuffer", target, attachment)
+	defer d.exit("InvalidateFramebuffer")
+	d.Functions.InvalidateFramebuffer(target, attachment)
+}
+
+func (d *debugFunctions) LinkProgram(p Program) {
+	d.enter("LinkProgram", p)
+	defer d.exit("LinkProgram")
+	d.Functions.LinkProgram(p)
+}
+
+func (d *debugFunctions) ShaderSource(s Shader, src string) {
+	d.enter("ShaderSource", s, src)
+	defer d.exit("ShaderSource")
+	d.Functions.ShaderSource(s, src)
+}
+
+func (d *debugFunctions) TexImage2D(target Enum, level, internalFormat, width, height int, format, ty Enum, data []byte) {
+	d.enter("TexImage2D", target, level, internalFormat, width, height, format, ty, len(data))
+	defer d.exit("TexImage2D")
+	d.Functions.TexImage2D(target, level, internalFormat, width, height, format, ty, data)
+}
+
+func (d *debugFunctions) TexParameteri(target, pname Enum, param int) {
+	d.enter("TexParameteri", target, pname, param)
+	defer d.exit("TexParameteri")
+	d.Functions.TexParameteri(target, pname, param)
+}
+
+func (d *debugFunctions) Uniform1f(dst Uniform, v float32) {
+	d.enter("Uniform1f", dst, v)
+	defer d.exit("Uniform1f")
+	d.Functions.Uniform1f(dst, v)
+}
+
+func (d *debugFunctions) Uniform1i(dst Uniform, v int) {
+	d.enter("Uniform1i", dst, v)
+	defer d.exit("Uniform1i")
+	d.Functions.Uniform1i(dst, v)
+}
+
+func (d *debugFunctions) Uniform2f(dst Uniform, v0, v1 float32) {
+	d.enter("Uniform2f", dst, v0, v1)
+	defer d.exit("Uniform2f")
+	d.Functions.Uniform2f(dst, v0, v1)
+}
+
+func (d *debugFunctions) Uniform3f(dst Uniform, v0, v1, v2 float32) {
+	d.enter("Uniform3f", dst, v0, v1, v2)
+	defer d.exit("Uniform3f")
+	d.Functions.Uniform3f(dst, v0, v1, v2)
+}
+
+func (d *debugFunctions) Uniform4f(dst Uniform, v0, v1, v2, v3 float32) {
+	d.enter("Uniform4f", dst, v0, v1, v2, v3)
+	defer d.exit("Uniform4f")
+	d.Functions.Uniform4f(dst, v0, v1, v2, v3)
+}
+
+func (d *debugFunctions) UseProgram(p Program) {
+	d.enter("UseProgram", p)
+	defer d.exit("UseProgram")
+	d.Functions.UseProgram(p)
+}
+
+func (d *debugFunctions) VertexAttribPointer(dst Attrib, size int, ty Enum, normalized bool, stride, offset int) {
+	d.enter("VertexAttribPointer", dst, size, ty, normalized, stride, offset)
+	defer d.exit("VertexAttribPointer")
+	d.Functions.VertexAttribPointer(dst, size, ty, normalized, stride, offset)
+}
+
+func (d *debugFunctions) Viewport(x, y, width, height int) {
+	d.enter("Viewport", x, y, width, height)
+	defer d.exit("Viewport")
+	d.Functions.Viewport(x, y, width, height)
+}
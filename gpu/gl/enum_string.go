@@ -0,0 +1,86 @@
+// SPDX-License-Identifier: Unlicense OR MIT
+
+package gl
+
+import "fmt"
+
+// String returns the symbolic GL constant name for e, or its hexadecimal
+// value if it isn't one of the constants this package uses. It exists
+// mainly to make gldebug traces and error messages readable.
+func (e Enum) String() string {
+	if s, ok := enumNames[e]; ok {
+		return s
+	}
+	return fmt.Sprintf("Enum(0x%x)", uint32(e))
+}
+
+// enumNames maps each distinct numeric Enum value this package uses to a
+// single canonical name. Several constants alias the same value (e.g.
+// NO_ERROR, ZERO and FALSE are all 0; ONE and TRUE are both 1); only one
+// entry per value can appear in the map, so the others are omitted.
+var enumNames = map[Enum]string{
+	NO_ERROR:                      "NO_ERROR",
+	INVALID_ENUM:                  "INVALID_ENUM",
+	INVALID_VALUE:                 "INVALID_VALUE",
+	INVALID_OPERATION:             "INVALID_OPERATION",
+	INVALID_FRAMEBUFFER_OPERATION: "INVALID_FRAMEBUFFER_OPERATION",
+	OUT_OF_MEMORY:                 "OUT_OF_MEMORY",
+	ONE:                           "ONE",
+	ONE_MINUS_SRC_ALPHA:           "ONE_MINUS_SRC_ALPHA",
+	DST_COLOR:                     "DST_COLOR",
+	TRIANGLES:                     "TRIANGLES",
+	TRIANGLE_STRIP:                "TRIANGLE_STRIP",
+	ARRAY_BUFFER:                  "ARRAY_BUFFER",
+	ELEMENT_ARRAY_BUFFER:          "ELEMENT_ARRAY_BUFFER",
+	UNIFORM_BUFFER:                "UNIFORM_BUFFER",
+	STATIC_DRAW:                   "STATIC_DRAW",
+	TEXTURE_2D:                    "TEXTURE_2D",
+	TEXTURE0:                      "TEXTURE0",
+	TEXTURE_MAG_FILTER:            "TEXTURE_MAG_FILTER",
+	TEXTURE_MIN_FILTER:            "TEXTURE_MIN_FILTER",
+	TEXTURE_WRAP_S:                "TEXTURE_WRAP_S",
+	TEXTURE_WRAP_T:                "TEXTURE_WRAP_T",
+	CLAMP_TO_EDGE:                 "CLAMP_TO_EDGE",
+	NEAREST:                       "NEAREST",
+	LINEAR:                        "LINEAR",
+	RGBA:                          "RGBA",
+	RED:                           "RED",
+	R16F:                          "R16F",
+	R8:                            "R8",
+	LUMINANCE:                     "LUMINANCE",
+	SRGB8_ALPHA8:                  "SRGB8_ALPHA8",
+	SRGB_ALPHA_EXT:                "SRGB_ALPHA_EXT",
+	HALF_FLOAT:                    "HALF_FLOAT",
+	HALF_FLOAT_OES:                "HALF_FLOAT_OES",
+	FLOAT:                         "FLOAT",
+	SHORT:                         "SHORT",
+	UNSIGNED_BYTE:                 "UNSIGNED_BYTE",
+	UNSIGNED_SHORT:                "UNSIGNED_SHORT",
+	FRAMEBUFFER:                   "FRAMEBUFFER",
+	FRAMEBUFFER_BINDING:           "FRAMEBUFFER_BINDING",
+	FRAMEBUFFER_COMPLETE:          "FRAMEBUFFER_COMPLETE",
+	COLOR_ATTACHMENT0:             "COLOR_ATTACHMENT0",
+	COLOR_BUFFER_BIT:              "COLOR_BUFFER_BIT",
+	DEPTH_BUFFER_BIT:              "DEPTH_BUFFER_BIT",
+	DEPTH_TEST:                    "DEPTH_TEST",
+	BLEND:                         "BLEND",
+	GREATER:                       "GREATER",
+	MAX_TEXTURE_SIZE:              "MAX_TEXTURE_SIZE",
+	EXTENSIONS:                    "EXTENSIONS",
+	VERSION:                       "VERSION",
+	TIME_ELAPSED_EXT:              "TIME_ELAPSED_EXT",
+	GPU_DISJOINT_EXT:              "GPU_DISJOINT_EXT",
+	QUERY_RESULT:                  "QUERY_RESULT",
+	QUERY_RESULT_AVAILABLE:        "QUERY_RESULT_AVAILABLE",
+	ANY_SAMPLES_PASSED:            "ANY_SAMPLES_PASSED",
+	DEBUG_OUTPUT:                  "DEBUG_OUTPUT",
+	DEBUG_OUTPUT_SYNCHRONOUS:      "DEBUG_OUTPUT_SYNCHRONOUS",
+	DEBUG_SOURCE_APPLICATION:      "DEBUG_SOURCE_APPLICATION",
+	FRAGMENT_SHADER:               "FRAGMENT_SHADER",
+	VERTEX_SHADER:                 "VERTEX_SHADER",
+	COMPILE_STATUS:                "COMPILE_STATUS",
+	LINK_STATUS:                   "LINK_STATUS",
+	TEXTURE:                       "TEXTURE",
+	BUFFER:                        "BUFFER",
+	PROGRAM:                       "PROGRAM",
+}
@@ -0,0 +1,111 @@
+// SPDX-License-Identifier: Unlicense OR MIT
+
+package gl
+
+// Enum is a value accepted or returned by most GL entry points, such as a
+// buffer target, texture format or error code.
+type Enum uint32
+
+// Object is the common representation of a GL object name, shared by the
+// handle types below and by APIs (such as glObjectLabel and GetBinding)
+// that operate on objects regardless of their specific kind.
+type Object uint32
+
+type (
+	Texture     Object
+	Buffer      Object
+	Framebuffer Object
+	Program     Object
+	Query       Object
+	Shader      Object
+)
+
+// Attrib is a vertex attribute location, as returned by
+// glGetAttribLocation.
+type Attrib int32
+
+// Uniform is a uniform location, as returned by glGetUniformLocation. A
+// negative location means the uniform was optimized out or doesn't exist,
+// mirroring glGetUniformLocation's -1 sentinel.
+type Uniform int32
+
+func (u Uniform) valid() bool {
+	return u >= 0
+}
+
+// INVALID_INDEX is returned by GetUniformBlockIndex when the named
+// uniform block doesn't exist in the program.
+const INVALID_INDEX uint32 = 0xFFFFFFFF
+
+// Functions is the subset of the OpenGL (ES) API the gl package needs,
+// implemented per-platform in terms of the native GL bindings.
+type Functions interface {
+	ActiveTexture(texture Enum)
+	AttachShader(p Program, s Shader)
+	BeginQuery(target Enum, query Query)
+	BindAttribLocation(p Program, a Attrib, name string)
+	BindBuffer(target Enum, buffer Buffer)
+	BindBufferBase(target Enum, index int, buffer Buffer)
+	BindBufferRange(target Enum, index int, buffer Buffer, offset, size int)
+	BindFramebuffer(target Enum, fbo Framebuffer)
+	BindTexture(target Enum, texture Texture)
+	BlendFunc(sfactor, dfactor Enum)
+	BufferData(target Enum, src []byte, usage Enum)
+	BufferSubData(target Enum, offset int, src []byte)
+	CheckFramebufferStatus(target Enum) Enum
+	Clear(mask Enum)
+	ClearColor(red, green, blue, alpha float32)
+	ClearDepthf(d float32)
+	CompileShader(s Shader)
+	CreateBuffer() Buffer
+	CreateFramebuffer() Framebuffer
+	CreateProgram() Program
+	CreateQuery() Query
+	CreateShader(ty Enum) Shader
+	CreateTexture() Texture
+	DeleteBuffer(v Buffer)
+	DeleteFramebuffer(v Framebuffer)
+	DeleteProgram(p Program)
+	DeleteQuery(v Query)
+	DeleteShader(s Shader)
+	DeleteTexture(v Texture)
+	DepthFunc(f Enum)
+	DepthMask(mask bool)
+	Disable(cap Enum)
+	DisableVertexAttribArray(a Attrib)
+	DrawArrays(mode Enum, first, count int)
+	DrawElements(mode Enum, count int, ty Enum, offset int)
+	Enable(cap Enum)
+	EnableVertexAttribArray(a Attrib)
+	EndQuery(target Enum)
+	FramebufferTexture2D(target, attachment, texTarget Enum, t Texture, level int)
+	GetBinding(pname Enum) Object
+	GetDebugMessageLog() (DebugMessage, bool)
+	GetError() Enum
+	GetInteger(pname Enum) int
+	GetProgrami(p Program, pname Enum) int
+	GetProgramInfoLog(p Program) string
+	GetQueryObjectuiv(query Query, pname Enum) uint
+	GetShaderi(s Shader, pname Enum) int
+	GetShaderInfoLog(s Shader) string
+	GetString(pname Enum) string
+	GetUniformBlockIndex(p Program, name string) uint32
+	GetUniformLocation(p Program, name string) Uniform
+	InvalidateFramebuffer(target, attachment Enum)
+	LinkProgram(p Program)
+	ObjectLabel(identifier Enum, obj Object, label string)
+	PopDebugGroup()
+	PushDebugGroup(source Enum, id uint32, message string)
+	ShaderSource(s Shader, src string)
+	TexImage2D(target Enum, level, internalFormat, width, height int, format, ty Enum, data []byte)
+	TexParameteri(target, pname Enum, param int)
+	Uniform1f(dst Uniform, v float32)
+	Uniform1i(dst Uniform, v int)
+	Uniform2f(dst Uniform, v0, v1 float32)
+	Uniform3f(dst Uniform, v0, v1, v2 float32)
+	Uniform4f(dst Uniform, v0, v1, v2, v3 float32)
+	UniformBlockBinding(p Program, index uint32, binding int)
+	UseProgram(p Program)
+	VertexAttribPointer(dst Attrib, size int, ty Enum, normalized bool, stride, offset int)
+	Viewport(x, y, width, height int)
+}
@@ -0,0 +1,89 @@
+// SPDX-License-Identifier: Unlicense OR MIT
+
+package gl
+
+// PushDebugGroup opens a named GL_KHR_debug group. Calls nest, and are
+// shown as such by GPU debuggers like RenderDoc and apitrace, which makes
+// it possible to tell which draw produced which warning. It is a no-op
+// when GL_KHR_debug isn't available.
+func (b *Backend) PushDebugGroup(name string) {
+	if !b.hasKHRDebug {
+		return
+	}
+	b.funcs.PushDebugGroup(DEBUG_SOURCE_APPLICATION, 0, name)
+}
+
+// PopDebugGroup closes the group opened by the matching PushDebugGroup.
+func (b *Backend) PopDebugGroup() {
+	if !b.hasKHRDebug {
+		return
+	}
+	b.funcs.PopDebugGroup()
+}
+
+// SetDebugMessageHandler registers f to receive GL_KHR_debug messages,
+// drained at the end of every frame. Passing nil disables delivery. It has
+// no effect when GL_KHR_debug isn't available.
+func (b *Backend) SetDebugMessageHandler(f func(source, msgType, severity Enum, id uint32, message string)) {
+	b.debugMessages = f
+}
+
+// drainDebugMessages empties the GL_KHR_debug log into the registered
+// handler, if any.
+func (b *Backend) drainDebugMessages() {
+	if !b.hasKHRDebug || b.debugMessages == nil {
+		return
+	}
+	for {
+		msg, ok := b.funcs.GetDebugMessageLog()
+		if !ok {
+			return
+		}
+		b.debugMessages(msg.Source, msg.Type, msg.Severity, msg.ID, msg.Message)
+	}
+}
+
+// objectLabel attaches a debugging name to a GL object, visible in tools
+// such as RenderDoc and apitrace. It is a no-op unless GL_KHR_debug is
+// available; glObjectLabel has no equivalent on GL_EXT_debug_label-only
+// contexts, which expose the incompatible glLabelObjectEXT entry point
+// instead.
+func (b *Backend) objectLabel(identifier Enum, obj Object, label string) {
+	if !b.hasKHRDebug {
+		return
+	}
+	b.funcs.ObjectLabel(identifier, obj, label)
+}
+
+// SetLabel names t for GPU debuggers.
+func (t *gpuTexture) SetLabel(name string) {
+	t.backend.objectLabel(TEXTURE, Object(t.obj), name)
+}
+
+// SetLabel names b for GPU debuggers. It is a no-op for emulated uniform
+// buffers, which have no GL object of their own.
+func (b *gpuBuffer) SetLabel(name string) {
+	if b.data != nil {
+		return
+	}
+	b.backend.objectLabel(BUFFER, Object(b.obj), name)
+}
+
+// SetLabel names p for GPU debuggers.
+func (p *gpuProgram) SetLabel(name string) {
+	p.backend.objectLabel(PROGRAM, Object(p.obj), name)
+}
+
+// SetLabel names f for GPU debuggers.
+func (f *gpuFramebuffer) SetLabel(name string) {
+	f.backend.objectLabel(FRAMEBUFFER, Object(f.obj), name)
+}
+
+// DebugMessage is a single entry drained from the GL_KHR_debug log.
+type DebugMessage struct {
+	Source   Enum
+	Type     Enum
+	ID       uint32
+	Severity Enum
+	Message  string
+}
@@ -0,0 +1,11 @@
+// SPDX-License-Identifier: Unlicense OR MIT
+
+//go:build !gldebug
+// +build !gldebug
+
+package gl
+
+// wrapDebug is a no-op without the gldebug build tag.
+func wrapDebug(f Functions) Functions {
+	return f
+}
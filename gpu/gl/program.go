@@ -0,0 +1,58 @@
+// SPDX-License-Identifier: Unlicense OR MIT
+
+package gl
+
+import "fmt"
+
+// CreateProgram compiles vsSrc and fsSrc and links them into a program.
+// attribs[i], if non-empty, is bound to vertex attribute location i before
+// linking.
+func CreateProgram(funcs Functions, vsSrc, fsSrc string, attribs []string) (Program, error) {
+	vs, err := createShader(funcs, VERTEX_SHADER, vsSrc)
+	if err != nil {
+		return Program(0), err
+	}
+	defer funcs.DeleteShader(vs)
+	fs, err := createShader(funcs, FRAGMENT_SHADER, fsSrc)
+	if err != nil {
+		return Program(0), err
+	}
+	defer funcs.DeleteShader(fs)
+
+	prog := funcs.CreateProgram()
+	funcs.AttachShader(prog, vs)
+	funcs.AttachShader(prog, fs)
+	for i, a := range attribs {
+		if a != "" {
+			funcs.BindAttribLocation(prog, Attrib(i), a)
+		}
+	}
+	funcs.LinkProgram(prog)
+	if funcs.GetProgrami(prog, LINK_STATUS) == 0 {
+		log := funcs.GetProgramInfoLog(prog)
+		funcs.DeleteProgram(prog)
+		return Program(0), fmt.Errorf("gl: program link failed: %s", log)
+	}
+	return prog, nil
+}
+
+// createShader compiles src as a shader of the given type, returning an
+// error with the driver's info log on failure.
+func createShader(funcs Functions, ty Enum, src string) (Shader, error) {
+	sh := funcs.CreateShader(ty)
+	funcs.ShaderSource(sh, src)
+	funcs.CompileShader(sh)
+	if funcs.GetShaderi(sh, COMPILE_STATUS) == 0 {
+		log := funcs.GetShaderInfoLog(sh)
+		funcs.DeleteShader(sh)
+		return Shader(0), fmt.Errorf("gl: shader compile failed: %s", log)
+	}
+	return sh, nil
+}
+
+// GetUniformLocation looks up the location of the uniform named name in p.
+// It is a thin wrapper around the Functions method of the same name, for
+// callers that only have a Functions value and not a *Backend.
+func GetUniformLocation(funcs Functions, p Program, name string) Uniform {
+	return funcs.GetUniformLocation(p, name)
+}
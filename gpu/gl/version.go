@@ -0,0 +1,36 @@
+// SPDX-License-Identifier: Unlicense OR MIT
+
+package gl
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ParseGLVersion parses a GL_VERSION string, such as "3.3.0 NVIDIA 390.147"
+// on desktop GL or "OpenGL ES 3.0 (placeholder)" on GLES, into its major and
+// minor version numbers.
+func ParseGLVersion(str string) ([2]int, error) {
+	s := str
+	if i := strings.Index(s, "OpenGL ES"); i != -1 {
+		s = s[i+len("OpenGL ES"):]
+	}
+	fields := strings.Fields(s)
+	if len(fields) == 0 {
+		return [2]int{}, fmt.Errorf("gl: failed to parse GL version %q", str)
+	}
+	parts := strings.SplitN(fields[0], ".", 3)
+	if len(parts) < 2 {
+		return [2]int{}, fmt.Errorf("gl: failed to parse GL version %q", str)
+	}
+	major, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return [2]int{}, fmt.Errorf("gl: failed to parse GL version %q: %w", str, err)
+	}
+	minor, err := strconv.Atoi(parts[1])
+	if err != nil {
+		return [2]int{}, fmt.Errorf("gl: failed to parse GL version %q: %w", str, err)
+	}
+	return [2]int{major, minor}, nil
+}
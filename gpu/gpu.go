@@ -0,0 +1,234 @@
+// SPDX-License-Identifier: Unlicense OR MIT
+
+// Package gpu implements the rendering pipeline shared by Gio's GPU
+// backends. It defines the backend-neutral interfaces (Backend, Texture,
+// Buffer, Program, ...) that each backend package, such as gpu/gl,
+// implements against its native graphics API.
+package gpu
+
+import (
+	"image"
+	"time"
+)
+
+// Backend is the interface a GPU backend must implement.
+type Backend interface {
+	BeginFrame()
+	EndFrame()
+	Caps() Caps
+	NewTimer() Timer
+	NewOcclusionQuery() OcclusionQuery
+	IsTimeContinuous() bool
+	NewFramebuffer() Framebuffer
+	NilTexture() Texture
+	DefaultFramebuffer() Framebuffer
+	NewTexture(format TextureFormat, width, height int, minFilter, magFilter TextureFilter) Texture
+	NewBuffer(typ BufferType, size int) Buffer
+	NewImmutableBuffer(typ BufferType, data []byte) Buffer
+	NewInputLayout(vs ShaderSources, layout []InputDesc) (InputLayout, error)
+	NewProgram(vssrc, fssrc ShaderSources) (Program, error)
+	SetDepthTest(enable bool)
+	BlendFunc(sfactor, dfactor BlendFactor)
+	DepthMask(mask bool)
+	SetBlend(enable bool)
+	DrawElements(mode DrawMode, off, count int)
+	DrawArrays(mode DrawMode, off, count int)
+	Viewport(x, y, width, height int)
+	Clear(attachments BufferAttachments)
+	ClearDepth(d float32)
+	ClearColor(r, g, b, a float32)
+	DepthFunc(f DepthFunc)
+}
+
+// Texture is a backend-allocated 2D texture.
+type Texture interface {
+	Upload(img *image.RGBA)
+	Bind(unit int)
+	Release()
+}
+
+// Buffer is a backend-allocated vertex, index or uniform buffer.
+type Buffer interface {
+	Upload(data []byte)
+	BindVertex(stride, offset int)
+	BindIndex()
+	Release()
+}
+
+// Framebuffer is a backend-allocated render target.
+type Framebuffer interface {
+	Bind()
+	Invalidate()
+	BindTexture(t Texture)
+	IsComplete() error
+	Release()
+}
+
+// Program is a compiled, linked vertex+fragment shader pair.
+type Program interface {
+	Bind()
+	SetVertexUniforms(buffer Buffer)
+	SetFragmentUniforms(buffer Buffer)
+	Release()
+}
+
+// InputLayout describes how vertex buffer data maps onto a Program's
+// vertex inputs.
+type InputLayout interface {
+	Release()
+}
+
+// Timer measures elapsed GPU time between a Begin and End.
+type Timer interface {
+	Begin()
+	End()
+	Duration() (time.Duration, bool)
+	Release()
+}
+
+// OcclusionQuery reports how many samples passed the depth/stencil tests
+// for the draws issued between its Begin and End, for picking and
+// visibility culling. Backends without true sample counts report a
+// boolean pass/fail (0 or 1) instead; FeatureOcclusion in Caps.Features
+// does not tell the two apart.
+type OcclusionQuery interface {
+	Begin()
+	End()
+	SamplesPassed() (uint32, bool)
+	Release()
+}
+
+// Caps describes the capabilities of a Backend.
+type Caps struct {
+	MaxTextureSize int
+	Features       Features
+}
+
+// Features is a bitmask of optional Backend capabilities.
+type Features int
+
+const (
+	// FeatureTimers indicates support for Timer.
+	FeatureTimers Features = 1 << iota
+	// FeatureOcclusion indicates support for OcclusionQuery.
+	FeatureOcclusion
+)
+
+// ShaderSources holds the sources for a vertex or fragment shader, one
+// per GLSL dialect a backend may target. GLES2 is mandatory; GLES3 and
+// GL330 are optional richer variants a backend picks when available,
+// falling back to GLES2 otherwise.
+type ShaderSources struct {
+	GLES2 string
+	GLES3 string
+	GL330 string
+
+	Inputs   []InputLocation
+	Textures []TextureBinding
+	Uniforms []UniformLocation
+	// UniformSize is the size in bytes of the uniform block described by
+	// Uniforms.
+	UniformSize int
+	// UniformsBlockName is the name of the corresponding uniform block in
+	// the shader source, used to resolve a uniform buffer object binding
+	// on backends with native UBO support.
+	UniformsBlockName string
+}
+
+// InputLocation describes a single vertex attribute of a ShaderSources.
+type InputLocation struct {
+	Name     string
+	Location int
+	Type     DataType
+	Size     int
+}
+
+// TextureBinding names a sampler uniform and the texture unit it's bound
+// to.
+type TextureBinding struct {
+	Name    string
+	Binding int
+}
+
+// UniformLocation describes a single uniform within a uniform block.
+type UniformLocation struct {
+	Name   string
+	Type   DataType
+	Size   int
+	Offset int
+}
+
+// InputDesc describes the memory layout of a single vertex attribute in a
+// vertex buffer.
+type InputDesc struct {
+	Type   DataType
+	Size   int
+	Offset int
+}
+
+// DataType is the scalar type backing a vertex attribute or uniform.
+type DataType int
+
+const (
+	DataTypeFloat DataType = iota
+	DataTypeShort
+)
+
+// TextureFormat selects the pixel format of a Backend.NewTexture.
+type TextureFormat int
+
+const (
+	TextureFormatFloat TextureFormat = iota
+	TextureFormatSRGB
+)
+
+// TextureFilter selects the minification/magnification filter of a
+// Backend.NewTexture.
+type TextureFilter int
+
+const (
+	FilterNearest TextureFilter = iota
+	FilterLinear
+)
+
+// BufferType selects the binding point of a Backend.NewBuffer.
+type BufferType int
+
+const (
+	BufferTypeVertices BufferType = iota
+	BufferTypeIndices
+	BufferTypeUniforms
+)
+
+// BlendFactor is an operand of Backend.BlendFunc.
+type BlendFactor int
+
+const (
+	BlendFactorOne BlendFactor = iota
+	BlendFactorOneMinusSrcAlpha
+	BlendFactorZero
+	BlendFactorDstColor
+)
+
+// DrawMode selects the primitive topology of a draw call.
+type DrawMode int
+
+const (
+	DrawModeTriangles DrawMode = iota
+	DrawModeTriangleStrip
+)
+
+// BufferAttachments is a bitmask of the attachments Backend.Clear clears.
+type BufferAttachments int
+
+const (
+	BufferAttachmentColor BufferAttachments = 1 << iota
+	BufferAttachmentDepth
+)
+
+// DepthFunc selects the comparison function of Backend.DepthFunc.
+type DepthFunc int
+
+const (
+	DepthFuncGreater DepthFunc = iota
+)